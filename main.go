@@ -3,36 +3,78 @@ package main
 import (
 	"fmt"
 	"os"
+	"runtime"
+
+	"github.com/spf13/pflag"
 
 	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: yamlvalidator <filename>")
+	configPath := pflag.String("config", "", "path to a YAML/JSON config overriding the built-in schema")
+	workers := pflag.IntP("jobs", "j", runtime.NumCPU(), "number of files to validate concurrently")
+	format := pflag.String("format", "text", "report format: text, json, junit, sarif, or github")
+	pflag.Parse()
+
+	if pflag.NArg() < 1 {
+		fmt.Println("Usage: yamlvalidator [--config path] [-j N] [--format text|json|junit|sarif|github] <file|glob|dir>...")
 		os.Exit(1)
 	}
 
-	filename := os.Args[1]
+	reporter, err := yamlvalidator.ReporterFor(*format)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		fmt.Printf("%s does not exist\n", filename)
+	schema, err := yamlvalidator.LoadSchema(*configPath)
+	if err != nil {
+		fmt.Printf("Error loading schema: %v\n", err)
 		os.Exit(1)
 	}
 
-	v, err := yamlvalidator.NewValidator(filename)
+	runner := NewRunner(schema, *workers)
+	results, err := runner.Run(pflag.Args())
 	if err != nil {
-		fmt.Printf("Error initializing validator: %v\n", err)
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	errors := v.Validate()
-	if len(errors) > 0 {
-		for _, err := range errors {
-			fmt.Println(err)
+	failed := false
+	reports := make([]yamlvalidator.FileReport, 0, len(results))
+	for _, res := range results {
+		if res.Err != nil {
+			fmt.Printf("Error initializing validator for %s: %v\n", res.FilePath, res.Err)
+			failed = true
+			continue
 		}
+		errs := yamlvalidator.ToValidationErrors(res.Errors)
+		for _, e := range errs {
+			if e.IsError() {
+				failed = true
+				break
+			}
+		}
+		reports = append(reports, yamlvalidator.FileReport{
+			FilePath: res.FilePath,
+			Errors:   errs,
+		})
+	}
+
+	output, err := reporter.Report(reports)
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
+	if output != "" {
+		fmt.Print(output)
+	}
 
-	fmt.Println("YAML file is valid")
+	if failed {
+		os.Exit(1)
+	}
+
+	if *format == "text" {
+		fmt.Printf("%d file(s) valid\n", len(results))
+	}
 }
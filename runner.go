@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+)
+
+// Result pairs a validated file with the errors Validate found in it. Err is
+// set instead of Errors when the validator itself could not be constructed
+// (e.g. the file could not be read or parsed).
+type Result struct {
+	FilePath string
+	Errors   []error
+	Err      error
+}
+
+// Runner resolves file/glob/directory arguments into concrete YAML files
+// and validates them concurrently across a worker pool, so the tool can
+// lint a whole repository instead of one file at a time.
+type Runner struct {
+	Schema  *yamlvalidator.Schema
+	Workers int
+}
+
+// NewRunner returns a Runner that validates against schema using workers
+// goroutines; workers is clamped to at least 1.
+func NewRunner(schema *yamlvalidator.Schema, workers int) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{Schema: schema, Workers: workers}
+}
+
+// Run resolves args - each a file path, glob pattern, or directory to walk
+// recursively for *.yaml/*.yml files - and validates the resulting files
+// across r.Workers goroutines. Results are returned in no particular order.
+func (r *Runner) Run(args []string) ([]Result, error) {
+	files, err := resolveFiles(args)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make(chan string)
+	results := make(chan Result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				results <- r.validateFile(file)
+			}
+		}()
+	}
+
+	go func() {
+		for _, file := range files {
+			jobs <- file
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	out := make([]Result, 0, len(files))
+	for res := range results {
+		out = append(out, res)
+	}
+
+	return out, nil
+}
+
+func (r *Runner) validateFile(file string) Result {
+	v, err := yamlvalidator.NewValidator(file, r.Schema)
+	if err != nil {
+		return Result{FilePath: file, Err: err}
+	}
+	// Use v.FilePath, not file, so Result.FilePath matches the FilePath every
+	// ValidationError it produced already carries - otherwise reporters that
+	// key off Result/FileReport disagree with reporters that key off the
+	// errors themselves whenever file has a directory part (v.FilePath is the
+	// bare file name).
+	return Result{FilePath: v.FilePath, Errors: v.Validate()}
+}
+
+// resolveFiles expands args into a sorted, de-duplicated list of *.yaml/
+// *.yml files: plain paths are taken as-is, directories are walked
+// recursively, and anything else is treated as a glob pattern.
+func resolveFiles(args []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, arg := range args {
+		info, statErr := os.Stat(arg)
+		switch {
+		case statErr == nil && info.IsDir():
+			walkErr := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() && isYAMLFile(path) {
+					add(path)
+				}
+				return nil
+			})
+			if walkErr != nil {
+				return nil, fmt.Errorf("cannot walk %s: %w", arg, walkErr)
+			}
+		case statErr == nil:
+			add(arg)
+		default:
+			matches, globErr := filepath.Glob(arg)
+			if globErr != nil || len(matches) == 0 {
+				return nil, fmt.Errorf("%s does not exist", arg)
+			}
+			for _, match := range matches {
+				add(match)
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const validConfigMap = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-config\ndata:\n  key1: value1\n"
+
+const invalidConfigMap = "apiVersion: v1\nkind: ConfigMap\nmetadata: {}\n"
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestResolveFiles_WalksDirRecursivelyAndDedups(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir nested: %v", err)
+	}
+
+	top := filepath.Join(dir, "a.yaml")
+	child := filepath.Join(nested, "b.yml")
+	writeFile(t, top, validConfigMap)
+	writeFile(t, child, validConfigMap)
+	writeFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	files, err := resolveFiles([]string{dir, top})
+	if err != nil {
+		t.Fatalf("resolveFiles() error = %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("resolveFiles() = %v, want 2 files", files)
+	}
+	for _, f := range files {
+		if filepath.Ext(f) == ".txt" {
+			t.Errorf("resolveFiles() included non-YAML file %s", f)
+		}
+	}
+}
+
+func TestRunner_Run_ValidatesFilesConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.yaml")
+	bad := filepath.Join(dir, "bad.yaml")
+	writeFile(t, good, validConfigMap)
+	writeFile(t, bad, invalidConfigMap)
+
+	runner := NewRunner(nil, 2)
+	results, err := runner.Run([]string{good, bad})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Run() returned %d results, want 2", len(results))
+	}
+
+	byName := make(map[string]Result, len(results))
+	for _, res := range results {
+		byName[filepath.Base(res.FilePath)] = res
+	}
+
+	if len(byName["good.yaml"].Errors) != 0 {
+		t.Errorf("good.yaml Errors = %v, want none", byName["good.yaml"].Errors)
+	}
+	if len(byName["bad.yaml"].Errors) == 0 {
+		t.Error("bad.yaml Errors = none, want at least one (missing metadata.name)")
+	}
+}
+
+func TestRunner_Run_UnknownPathErrors(t *testing.T) {
+	runner := NewRunner(nil, 1)
+	if _, err := runner.Run([]string{filepath.Join(t.TempDir(), "missing-*.yaml")}); err == nil {
+		t.Error("Run() with no matches = nil error, want non-nil")
+	}
+}
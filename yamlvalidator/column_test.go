@@ -0,0 +1,52 @@
+package yamlvalidator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+)
+
+// TestValidator_Validate_ColumnPreciseErrorWithSnippet pins down the
+// behavior the goccy/go-yaml migration was for: errors carry the exact
+// line/column of the offending node (not just the line), and a rendered
+// source snippet with a caret under that column.
+func TestValidator_Validate_ColumnPreciseErrorWithSnippet(t *testing.T) {
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-config\ndata:\n  Bad_Key!: value\n"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "configmap.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	v, err := yamlvalidator.NewValidator(path, nil)
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	errs := yamlvalidator.ToValidationErrors(v.Validate())
+	if len(errs) != 1 {
+		t.Fatalf("Validate() produced %d errors, want 1: %v", len(errs), errs)
+	}
+
+	e := errs[0]
+	if e.Line != 6 {
+		t.Errorf("Line = %d, want 6", e.Line)
+	}
+	// "  Bad_Key!: value" - the key starts at column 3.
+	if e.Column != 3 {
+		t.Errorf("Column = %d, want 3", e.Column)
+	}
+
+	wantSnippetLine := "  Bad_Key!: value"
+	if !strings.HasPrefix(e.Snippet, wantSnippetLine) {
+		t.Errorf("Snippet = %q, want it to start with %q", e.Snippet, wantSnippetLine)
+	}
+	caretLine := strings.SplitN(e.Snippet, "\n", 2)[1]
+	if caretLine != strings.Repeat(" ", e.Column-1)+"^" {
+		t.Errorf("Snippet caret line = %q, want a caret at column %d", caretLine, e.Column)
+	}
+}
@@ -0,0 +1,75 @@
+package yamlvalidator
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/viper"
+)
+
+// LoadSchema builds a Schema by layering, from lowest to highest priority:
+// DefaultSchema, a config file at configPath (YAML or JSON, if non-empty),
+// and environment variables prefixed YAMLVALIDATOR_ (e.g.
+// YAMLVALIDATOR_APIVERSION, YAMLVALIDATOR_PORTNUMBERMAX). This lets a
+// binary built once be retargeted at a different image registry or naming
+// policy without recompiling.
+func LoadSchema(configPath string) (*Schema, error) {
+	def := DefaultSchema()
+
+	v := viper.New()
+	v.SetEnvPrefix("YAMLVALIDATOR")
+	v.AutomaticEnv()
+
+	v.SetDefault("apiVersion", def.APIVersion)
+	v.SetDefault("kind", def.Kind)
+	v.SetDefault("supportedOSNames", def.SupportedOSNames)
+	v.SetDefault("supportedProtocols", def.SupportedProtocols)
+	v.SetDefault("nameRegex", def.NameRegex.String())
+	v.SetDefault("imageRegex", def.ImageRegex.String())
+	v.SetDefault("memoryRegex", def.MemoryRegex.String())
+	v.SetDefault("pathRegex", def.PathRegex.String())
+	v.SetDefault("portNumberMin", def.PortNumberMin)
+	v.SetDefault("portNumberMax", def.PortNumberMax)
+	v.SetDefault("crdSchemaPath", def.CRDSchemaPath)
+
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("cannot read config file: %w", err)
+		}
+	}
+
+	nameRegex, err := regexp.Compile(v.GetString("nameRegex"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid nameRegex: %w", err)
+	}
+
+	imageRegex, err := regexp.Compile(v.GetString("imageRegex"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid imageRegex: %w", err)
+	}
+
+	memoryRegex, err := regexp.Compile(v.GetString("memoryRegex"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid memoryRegex: %w", err)
+	}
+
+	pathRegex, err := regexp.Compile(v.GetString("pathRegex"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pathRegex: %w", err)
+	}
+
+	return &Schema{
+		APIVersion:         v.GetString("apiVersion"),
+		Kind:               v.GetString("kind"),
+		SupportedOSNames:   v.GetStringSlice("supportedOSNames"),
+		SupportedProtocols: v.GetStringSlice("supportedProtocols"),
+		NameRegex:          nameRegex,
+		ImageRegex:         imageRegex,
+		MemoryRegex:        memoryRegex,
+		PathRegex:          pathRegex,
+		PortNumberMin:      v.GetInt("portNumberMin"),
+		PortNumberMax:      v.GetInt("portNumberMax"),
+		CRDSchemaPath:      v.GetString("crdSchemaPath"),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package yamlvalidator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+)
+
+func TestLoadSchema_Defaults(t *testing.T) {
+	schema, err := yamlvalidator.LoadSchema("")
+	if err != nil {
+		t.Fatalf("LoadSchema(\"\") error = %v", err)
+	}
+
+	def := yamlvalidator.DefaultSchema()
+	if schema.APIVersion != def.APIVersion || schema.Kind != def.Kind {
+		t.Errorf("LoadSchema(\"\") = %+v, want defaults %+v", schema, def)
+	}
+}
+
+func TestLoadSchema_ConfigFileOverridesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "schema.yaml")
+	content := "apiVersion: apps/v1\nkind: Deployment\nportNumberMin: 1024\n"
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config fixture: %v", err)
+	}
+
+	schema, err := yamlvalidator.LoadSchema(configPath)
+	if err != nil {
+		t.Fatalf("LoadSchema(%q) error = %v", configPath, err)
+	}
+
+	if schema.APIVersion != "apps/v1" || schema.Kind != "Deployment" {
+		t.Errorf("APIVersion/Kind = %s/%s, want apps/v1/Deployment", schema.APIVersion, schema.Kind)
+	}
+	if schema.PortNumberMin != 1024 {
+		t.Errorf("PortNumberMin = %d, want 1024", schema.PortNumberMin)
+	}
+	if schema.PortNumberMax != yamlvalidator.PortNumberMax {
+		t.Errorf("PortNumberMax = %d, want default %d left untouched", schema.PortNumberMax, yamlvalidator.PortNumberMax)
+	}
+}
+
+func TestLoadSchema_InvalidRegexErrors(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "schema.yaml")
+	if err := os.WriteFile(configPath, []byte("nameRegex: \"(\"\n"), 0o644); err != nil {
+		t.Fatalf("write config fixture: %v", err)
+	}
+
+	if _, err := yamlvalidator.LoadSchema(configPath); err == nil {
+		t.Error("LoadSchema() with invalid nameRegex = nil error, want non-nil")
+	}
+}
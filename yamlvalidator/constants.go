@@ -12,6 +12,7 @@ var (
 	RegexImage        = regexp.MustCompile(`^registry\.bigbrother\.io/(.+):(.+)$`)
 	RegexMemory       = regexp.MustCompile(`^(\d+)(Mi|Gi|Ki)$`)
 	RegexAbsolutePath = regexp.MustCompile(`^/.*`)
+	RegexDNSSubdomain = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
 
 	SupportedOSNames   = []string{"linux", "windows"}
 	SupportedProtocols = []string{"TCP", "UDP"}
@@ -0,0 +1,45 @@
+package yamlvalidator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+)
+
+func TestValidator_Validate_MultiDocumentStream(t *testing.T) {
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n" +
+		"---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata: {}\n"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "multidoc.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	v, err := yamlvalidator.NewValidator(path, nil)
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	errs := yamlvalidator.ToValidationErrors(v.Validate())
+
+	var firstDocErrs, secondDocErrs int
+	for _, e := range errs {
+		switch e.Document {
+		case 0:
+			firstDocErrs++
+		case 1:
+			secondDocErrs++
+		}
+	}
+
+	if firstDocErrs != 0 {
+		t.Errorf("document 0 (valid) produced %d errors, want 0", firstDocErrs)
+	}
+	if secondDocErrs == 0 {
+		t.Error("document 1 (missing metadata.name) produced 0 errors, want at least 1")
+	}
+}
@@ -2,14 +2,37 @@ package yamlvalidator
 
 import "fmt"
 
+// LevelInfo marks a ValidationError as informational rather than a failure -
+// e.g. a document whose kind isn't recognized. Any other Level, including
+// the zero value "", counts as a failure, so existing rules that never set
+// it keep failing builds as before.
+const LevelInfo = "info"
+
+// ValidationError reports one rule violation found at a specific position in
+// a file. Snippet, when non-empty, is a pre-rendered source line with a caret
+// under Column, ready for Reporters that want a rustc-style code frame.
 type ValidationError struct {
 	FilePath string
+	Document int
 	Line     int
+	Column   int
+	RuleID   string
 	Message  string
+	Snippet  string
+	Level    string
 }
 
 func (e *ValidationError) Error() string {
-	return fmt.Sprintf("%s:%d %s", e.FilePath, e.Line, e.Message)
+	if e.Document > 0 {
+		return fmt.Sprintf("%s#%d:%d:%d %s", e.FilePath, e.Document, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d %s", e.FilePath, e.Line, e.Column, e.Message)
+}
+
+// IsError reports whether e should count as a failure rather than a purely
+// informational notice.
+func (e *ValidationError) IsError() bool {
+	return e.Level != LevelInfo
 }
 
 type ErrorCollector struct {
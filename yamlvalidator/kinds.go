@@ -0,0 +1,29 @@
+package yamlvalidator
+
+// kindKey identifies a document by the apiVersion and kind fields
+// Validator.Validate peeks at before picking how to validate it.
+type kindKey struct {
+	APIVersion string
+	Kind       string
+}
+
+// kindSpec describes a known kind's root shape: the top-level fields its
+// document must have, dispatched through the same RuleRegistry as any other
+// field under a Selector{Kind: <the kind>, Path: ...}.
+type kindSpec struct {
+	RequiredFields []string
+}
+
+// kindDispatcher maps a document's (apiVersion, kind) to its kindSpec. It
+// replaces the old behavior of calling validatePod unconditionally on every
+// top-level document regardless of what kind it actually declared. schema's
+// own APIVersion/Kind stay configurable as before; Deployment, Service and
+// ConfigMap are new, fixed additions alongside it.
+func kindDispatcher(schema Schema) map[kindKey]kindSpec {
+	return map[kindKey]kindSpec{
+		{APIVersion: schema.APIVersion, Kind: schema.Kind}: {RequiredFields: []string{"apiVersion", "kind", "metadata", "spec"}},
+		{APIVersion: "apps/v1", Kind: "Deployment"}:        {RequiredFields: []string{"apiVersion", "kind", "metadata", "spec"}},
+		{APIVersion: "v1", Kind: "Service"}:                {RequiredFields: []string{"apiVersion", "kind", "metadata", "spec"}},
+		{APIVersion: "v1", Kind: "ConfigMap"}:              {RequiredFields: []string{"apiVersion", "kind", "metadata"}},
+	}
+}
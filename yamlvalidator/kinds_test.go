@@ -0,0 +1,159 @@
+package yamlvalidator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+)
+
+func validateContent(t *testing.T, content string) []*yamlvalidator.ValidationError {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	v, err := yamlvalidator.NewValidator(path, nil)
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+	return yamlvalidator.ToValidationErrors(v.Validate())
+}
+
+func TestValidator_Validate_ValidDeployment(t *testing.T) {
+	content := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: my-app
+  template:
+    metadata:
+      labels:
+        app: my-app
+    spec:
+      containers:
+        - name: my_app
+          image: registry.bigbrother.io/my-app:v1
+          resources: {}
+`
+	if errs := validateContent(t, content); len(errs) != 0 {
+		t.Errorf("valid Deployment produced errors: %v", errs)
+	}
+}
+
+func TestValidator_Validate_DeploymentSelectorMismatch(t *testing.T) {
+	content := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: my-app
+  template:
+    metadata:
+      labels:
+        app: other-app
+    spec:
+      containers:
+        - name: my_app
+          image: registry.bigbrother.io/my-app:v1
+          resources: {}
+`
+	errs := validateContent(t, content)
+	if len(errs) == 0 {
+		t.Error("Deployment with mismatched selector/template labels produced no errors")
+	}
+}
+
+func TestValidator_Validate_ValidService(t *testing.T) {
+	content := `apiVersion: v1
+kind: Service
+metadata:
+  name: my-svc
+spec:
+  type: ClusterIP
+  selector:
+    app: my-app
+  ports:
+    - port: 80
+      targetPort: 8080
+      protocol: TCP
+`
+	if errs := validateContent(t, content); len(errs) != 0 {
+		t.Errorf("valid Service produced errors: %v", errs)
+	}
+}
+
+func TestValidator_Validate_ServiceUnsupportedType(t *testing.T) {
+	content := `apiVersion: v1
+kind: Service
+metadata:
+  name: my-svc
+spec:
+  type: Bogus
+  selector:
+    app: my-app
+  ports:
+    - port: 80
+`
+	errs := validateContent(t, content)
+	if len(errs) == 0 {
+		t.Error("Service with unsupported spec.type produced no errors")
+	}
+}
+
+func TestValidator_Validate_ValidConfigMap(t *testing.T) {
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-config\ndata:\n  key: value\n"
+	if errs := validateContent(t, content); len(errs) != 0 {
+		t.Errorf("valid ConfigMap produced errors: %v", errs)
+	}
+}
+
+func TestValidator_Validate_UnknownKindWithoutCRDSchemaIsInfo(t *testing.T) {
+	content := "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: my-widget\n"
+	errs := validateContent(t, content)
+	if len(errs) != 1 {
+		t.Fatalf("unknown kind produced %d errors, want 1: %v", len(errs), errs)
+	}
+	if errs[0].IsError() {
+		t.Errorf("unknown kind error Level = %q, want an informational (non-failing) error", errs[0].Level)
+	}
+}
+
+func TestValidator_Validate_UnknownKindWithCRDSchema(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "widget-schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"required": ["spec"]}`), 0o644); err != nil {
+		t.Fatalf("write CRD schema fixture: %v", err)
+	}
+	docPath := filepath.Join(dir, "widget.yaml")
+	if err := os.WriteFile(docPath, []byte("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: my-widget\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	schema := yamlvalidator.DefaultSchema()
+	schema.CRDSchemaPath = schemaPath
+
+	v, err := yamlvalidator.NewValidator(docPath, &schema)
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	errs := yamlvalidator.ToValidationErrors(v.Validate())
+	if len(errs) != 1 {
+		t.Fatalf("Widget missing spec produced %d errors, want 1: %v", len(errs), errs)
+	}
+	if !errs[0].IsError() {
+		t.Error("CRD-checked missing required field should be a failing error, not informational")
+	}
+}
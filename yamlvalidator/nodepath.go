@@ -0,0 +1,55 @@
+package yamlvalidator
+
+import "github.com/goccy/go-yaml/ast"
+
+// lookupChild returns the value mapped to key in node, or nil if node isn't
+// a mapping or has no such key.
+func lookupChild(node ast.Node, key string) ast.Node {
+	mapNode, ok := node.(ast.MapNode)
+	if !ok {
+		return nil
+	}
+
+	it := mapNode.MapRange()
+	for it.Next() {
+		if k, ok := scalarString(it.Key()); ok && k == key {
+			return it.Value()
+		}
+	}
+	return nil
+}
+
+// lookupPath walks node through each key in path in turn, the way
+// selectorMatchRule reaches into a Deployment's spec to compare sibling
+// fields that aren't on its own Selector path.
+func lookupPath(node ast.Node, path ...string) ast.Node {
+	cur := node
+	for _, key := range path {
+		if cur == nil {
+			return nil
+		}
+		cur = lookupChild(cur, key)
+	}
+	return cur
+}
+
+// stringMap reads node as a mapping of scalar keys to scalar values, the
+// shape a set of Kubernetes labels or a selector always takes. It returns
+// nil if node isn't a mapping.
+func stringMap(node ast.Node) map[string]string {
+	mapNode, ok := node.(ast.MapNode)
+	if !ok {
+		return nil
+	}
+
+	out := make(map[string]string)
+	it := mapNode.MapRange()
+	for it.Next() {
+		key, kok := scalarString(it.Key())
+		value, vok := scalarString(it.Value())
+		if kok && vok {
+			out[key] = value
+		}
+	}
+	return out
+}
@@ -0,0 +1,281 @@
+package yamlvalidator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// FileReport pairs a validated file with the ValidationErrors found in it,
+// the unit every Reporter renders a batch of.
+type FileReport struct {
+	FilePath string
+	Errors   []*ValidationError
+}
+
+// ToValidationErrors converts the []error returned by Validator.Validate
+// back into the concrete *ValidationError type Reporters need (every error
+// ErrorCollector holds is always a *ValidationError).
+func ToValidationErrors(errs []error) []*ValidationError {
+	out := make([]*ValidationError, 0, len(errs))
+	for _, err := range errs {
+		if ve, ok := err.(*ValidationError); ok {
+			out = append(out, ve)
+		}
+	}
+	return out
+}
+
+// Reporter renders a batch of FileReports for a particular consumer - a CI
+// log, a code-scanning dashboard, or a test results viewer.
+type Reporter interface {
+	Report(reports []FileReport) (string, error)
+}
+
+// ReporterFor looks up the Reporter registered under name. An empty name
+// selects the default TextReporter.
+func ReporterFor(name string) (Reporter, error) {
+	switch name {
+	case "", "text":
+		return TextReporter{}, nil
+	case "json":
+		return JSONReporter{}, nil
+	case "junit":
+		return JUnitReporter{}, nil
+	case "sarif":
+		return SARIFReporter{}, nil
+	case "github":
+		return GitHubReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q", name)
+	}
+}
+
+// TextReporter renders errors one per line as "file:line:col message",
+// followed by a rustc-style source snippet with a caret under the
+// offending column when one is available.
+type TextReporter struct{}
+
+func (TextReporter) Report(reports []FileReport) (string, error) {
+	var b strings.Builder
+	for _, r := range reports {
+		for _, e := range r.Errors {
+			b.WriteString(e.Error())
+			b.WriteByte('\n')
+			if e.Snippet != "" {
+				b.WriteString(e.Snippet)
+				b.WriteByte('\n')
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// JSONReporter renders errors as a flat JSON array, one object per error.
+type JSONReporter struct{}
+
+type jsonValidationError struct {
+	File     string `json:"file"`
+	Document int    `json:"document"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	RuleID   string `json:"ruleId"`
+	Message  string `json:"message"`
+	Level    string `json:"level,omitempty"`
+}
+
+func (JSONReporter) Report(reports []FileReport) (string, error) {
+	out := make([]jsonValidationError, 0)
+	for _, r := range reports {
+		for _, e := range r.Errors {
+			out = append(out, jsonValidationError{
+				File:     r.FilePath,
+				Document: e.Document,
+				Line:     e.Line,
+				Column:   e.Column,
+				RuleID:   e.RuleID,
+				Message:  e.Message,
+				Level:    e.Level,
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal JSON report: %w", err)
+	}
+	return string(data), nil
+}
+
+// JUnitReporter renders one testcase per file, so CI systems that already
+// understand JUnit XML (Jenkins, GitLab) can display validation results
+// alongside other test suites.
+type JUnitReporter struct{}
+
+type junitTestsuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestcase `xml:"testcase"`
+}
+
+type junitTestcase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+func (JUnitReporter) Report(reports []FileReport) (string, error) {
+	suite := junitTestsuite{Name: "yamlvalidator", Tests: len(reports)}
+
+	for _, r := range reports {
+		tc := junitTestcase{Name: r.FilePath, Classname: "yamlvalidator"}
+
+		var failures int
+		messages := make([]string, 0, len(r.Errors))
+		for _, e := range r.Errors {
+			if e.IsError() {
+				failures++
+			}
+			messages = append(messages, e.Error())
+		}
+
+		if failures > 0 {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d validation error(s)", failures),
+				Content: strings.Join(messages, "\n"),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal JUnit report: %w", err)
+	}
+	return xml.Header + string(data), nil
+}
+
+// SARIFReporter renders a SARIF 2.1.0 log so the validator can be wired
+// into GitHub code scanning, GitLab, or any other SARIF-consuming tool.
+type SARIFReporter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevel maps a ValidationError's Level to SARIF's level vocabulary:
+// "note" for informational findings, "error" for everything else.
+func sarifLevel(e *ValidationError) string {
+	if e.Level == LevelInfo {
+		return "note"
+	}
+	return "error"
+}
+
+func (SARIFReporter) Report(reports []FileReport) (string, error) {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "yamlvalidator"}},
+		Results: make([]sarifResult, 0),
+	}
+
+	for _, r := range reports {
+		for _, e := range r.Errors {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  e.RuleID,
+				Level:   sarifLevel(e),
+				Message: sarifMessage{Text: e.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: r.FilePath},
+						Region:           sarifRegion{StartLine: e.Line, StartColumn: e.Column},
+					},
+				}},
+			})
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal SARIF report: %w", err)
+	}
+	return string(data), nil
+}
+
+// GitHubReporter renders GitHub Actions workflow commands
+// (::error file=...,line=...,col=...::message) so failures show up as inline
+// annotations on the pull request diff. Informational errors render as
+// ::notice instead, so they show up without marking the step failed.
+type GitHubReporter struct{}
+
+func (GitHubReporter) Report(reports []FileReport) (string, error) {
+	var b strings.Builder
+	for _, r := range reports {
+		for _, e := range r.Errors {
+			command := "error"
+			if e.Level == LevelInfo {
+				command = "notice"
+			}
+			fmt.Fprintf(&b, "::%s file=%s,line=%d,col=%d::%s\n", command, r.FilePath, e.Line, e.Column, e.Message)
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,156 @@
+package yamlvalidator_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+)
+
+func sampleReports() []yamlvalidator.FileReport {
+	return []yamlvalidator.FileReport{
+		{
+			FilePath: "pod.yaml",
+			Errors: []*yamlvalidator.ValidationError{
+				{FilePath: "pod.yaml", Line: 3, Column: 5, RuleID: "pod.metadata.name", Message: "name is required"},
+			},
+		},
+	}
+}
+
+func TestReporterFor_UnknownFormat(t *testing.T) {
+	if _, err := yamlvalidator.ReporterFor("yaml"); err == nil {
+		t.Error(`ReporterFor("yaml") = nil error, want non-nil`)
+	}
+}
+
+func TestJSONReporter_Report(t *testing.T) {
+	out, err := yamlvalidator.JSONReporter{}.Report(sampleReports())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var decoded []struct {
+		File    string `json:"file"`
+		Line    int    `json:"line"`
+		Column  int    `json:"column"`
+		RuleID  string `json:"ruleId"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+
+	if len(decoded) != 1 {
+		t.Fatalf("decoded %d entries, want 1", len(decoded))
+	}
+	if decoded[0].File != "pod.yaml" || decoded[0].RuleID != "pod.metadata.name" {
+		t.Errorf("entry = %+v, want file=pod.yaml ruleId=pod.metadata.name", decoded[0])
+	}
+}
+
+func TestSARIFReporter_Report(t *testing.T) {
+	out, err := yamlvalidator.SARIFReporter{}.Report(sampleReports())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var decoded struct {
+		Schema string `json:"$schema"`
+		Runs   []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("output is not valid SARIF JSON: %v\n%s", err, out)
+	}
+
+	if decoded.Schema == "" {
+		t.Error("$schema is empty, want a SARIF schema URI")
+	}
+	if len(decoded.Runs) != 1 || len(decoded.Runs[0].Results) != 1 {
+		t.Fatalf("decoded = %+v, want exactly 1 run with 1 result", decoded)
+	}
+	result := decoded.Runs[0].Results[0]
+	if result.RuleID != "pod.metadata.name" {
+		t.Errorf("Results[0].RuleID = %q, want pod.metadata.name", result.RuleID)
+	}
+	if result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "pod.yaml" {
+		t.Errorf("Results[0] artifact URI = %q, want pod.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+}
+
+func TestJUnitReporter_Report(t *testing.T) {
+	out, err := yamlvalidator.JUnitReporter{}.Report(sampleReports())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if !strings.HasPrefix(out, xml.Header) {
+		t.Error("output does not start with the XML header")
+	}
+
+	var decoded struct {
+		XMLName  xml.Name `xml:"testsuite"`
+		Tests    int      `xml:"tests,attr"`
+		Failures int      `xml:"failures,attr"`
+		Cases    []struct {
+			Name    string `xml:"name,attr"`
+			Failure *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	}
+	if err := xml.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("output is not valid JUnit XML: %v\n%s", err, out)
+	}
+
+	if decoded.Tests != 1 || decoded.Failures != 1 {
+		t.Errorf("Tests/Failures = %d/%d, want 1/1", decoded.Tests, decoded.Failures)
+	}
+	if len(decoded.Cases) != 1 || decoded.Cases[0].Failure == nil {
+		t.Fatalf("testcase = %+v, want exactly 1 with a failure", decoded.Cases)
+	}
+}
+
+func TestGitHubReporter_Report(t *testing.T) {
+	out, err := yamlvalidator.GitHubReporter{}.Report(sampleReports())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	want := "::error file=pod.yaml,line=3,col=5::name is required\n"
+	if out != want {
+		t.Errorf("Report() = %q, want %q", out, want)
+	}
+}
+
+func TestGitHubReporter_Report_InfoLevelIsNotice(t *testing.T) {
+	reports := []yamlvalidator.FileReport{
+		{
+			FilePath: "pod.yaml",
+			Errors: []*yamlvalidator.ValidationError{
+				{FilePath: "pod.yaml", Line: 1, Column: 1, Message: "unknown kind", Level: yamlvalidator.LevelInfo},
+			},
+		},
+	}
+
+	out, err := yamlvalidator.GitHubReporter{}.Report(reports)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if !strings.HasPrefix(out, "::notice ") {
+		t.Errorf("Report() = %q, want a ::notice command for an info-level error", out)
+	}
+}
@@ -0,0 +1,53 @@
+package yamlvalidator_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator"
+	"github.com/DmitryFedoroff/go-devops-engineer-magistr-lesson2-tpl/yamlvalidator/mocks"
+)
+
+// TestRuleRegistry_RulesFor_InvokesRegisteredRule exercises RuleRegistry
+// through the Rule interface alone: it registers a mocks.Rule in place of a
+// real rule, fetches it back via RulesFor, and asserts Check is called with
+// the ctx/node it was given and that the registry surfaces whatever errors
+// the rule returns.
+func TestRuleRegistry_RulesFor_InvokesRegisteredRule(t *testing.T) {
+	file, err := parser.ParseBytes([]byte("spec:\n  replicas: 1\n"), 0)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	node := file.Docs[0].Body
+
+	ctx := &yamlvalidator.ValidationContext{FilePath: "fixture.yaml", Kind: "Deployment", Path: "spec"}
+	want := []*yamlvalidator.ValidationError{{FilePath: "fixture.yaml", RuleID: "deployment.spec", Message: "boom"}}
+
+	rule := mocks.NewRule(t)
+	rule.On("Check", ctx, node).Return(want)
+
+	registry := yamlvalidator.NewRuleRegistry()
+	sel := yamlvalidator.Selector{Kind: "Deployment", Path: "spec"}
+	registry.Register(sel, rule)
+
+	rules := registry.RulesFor(sel)
+	if len(rules) != 1 {
+		t.Fatalf("RulesFor() returned %d rules, want 1", len(rules))
+	}
+
+	got := rules[0].Check(ctx, node)
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Check() = %v, want %v", got, want)
+	}
+}
+
+// TestRuleRegistry_RulesFor_Unregistered confirms RulesFor returns nil, and
+// never touches a Rule, when no rule was registered for the selector.
+func TestRuleRegistry_RulesFor_Unregistered(t *testing.T) {
+	registry := yamlvalidator.NewRuleRegistry()
+
+	if rules := registry.RulesFor(yamlvalidator.Selector{Kind: "Pod", Path: "spec"}); rules != nil {
+		t.Errorf("RulesFor() = %v, want nil", rules)
+	}
+}
@@ -0,0 +1,76 @@
+package yamlvalidator
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// dataKeysRule checks that every key of a ConfigMap's data mapping is a
+// valid DNS subdomain, the same constraint the Kubernetes API server
+// enforces on ConfigMap keys.
+type dataKeysRule struct{}
+
+func (dataKeysRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	mapNode, ok := node.(ast.MapNode)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "data must be a mapping")}
+	}
+
+	var errs []*ValidationError
+	it := mapNode.MapRange()
+	for it.Next() {
+		key, ok := scalarString(it.Key())
+		if !ok || !RegexDNSSubdomain.MatchString(key) {
+			errs = append(errs, ctx.errorAt(it.Key(), "", fmt.Sprintf("data key '%s' is not a valid DNS subdomain", key)))
+		}
+	}
+	return errs
+}
+
+// binaryDataRule checks that every key of a ConfigMap's binaryData mapping
+// is a valid DNS subdomain and every value is base64-encoded.
+type binaryDataRule struct{}
+
+func (binaryDataRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	mapNode, ok := node.(ast.MapNode)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "binaryData must be a mapping")}
+	}
+
+	var errs []*ValidationError
+	it := mapNode.MapRange()
+	for it.Next() {
+		key, kok := scalarString(it.Key())
+		if !kok || !RegexDNSSubdomain.MatchString(key) {
+			errs = append(errs, ctx.errorAt(it.Key(), "", fmt.Sprintf("binaryData key '%s' is not a valid DNS subdomain", key)))
+			continue
+		}
+
+		value, vok := scalarString(it.Value())
+		if !vok {
+			errs = append(errs, ctx.errorAt(it.Value(), "", fmt.Sprintf("binaryData['%s'] must be string", key)))
+			continue
+		}
+
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			errs = append(errs, ctx.errorAt(it.Value(), "", fmt.Sprintf("binaryData['%s'] is not valid base64", key)))
+		}
+	}
+	return errs
+}
+
+// registerConfigMapRules registers the v1 ConfigMap rules: apiVersion/kind/
+// metadata like any other kind, plus data and binaryData key/value checks.
+func registerConfigMapRules(reg *RuleRegistry) {
+	const kind = "ConfigMap"
+
+	reg.Register(Selector{Kind: kind, Path: "apiVersion"}, apiVersionRule{Expected: "v1"})
+	reg.Register(Selector{Kind: kind, Path: "kind"}, kindRule{Expected: kind})
+	reg.Register(Selector{Kind: kind, Path: "metadata"}, mappingRule{RequiredFields: []string{"name"}})
+	reg.Register(Selector{Kind: kind, Path: "metadata.name"}, nameRule{})
+	reg.Register(Selector{Kind: kind, Path: "metadata.labels"}, labelsRule{})
+	reg.Register(Selector{Kind: kind, Path: "data"}, dataKeysRule{})
+	reg.Register(Selector{Kind: kind, Path: "binaryData"}, binaryDataRule{})
+}
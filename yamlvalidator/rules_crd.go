@@ -0,0 +1,60 @@
+package yamlvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml/ast"
+
+	goyaml "github.com/goccy/go-yaml"
+)
+
+// crdSchema is the minimal shape this package understands from an external
+// OpenAPI/JSON-Schema document: just the top-level "required" property
+// names. Checking anything deeper - types, nested properties, enums - would
+// need a real JSON Schema validator, which is out of scope for the
+// structural smoke check a CRD path can reasonably do here.
+type crdSchema struct {
+	Required []string `json:"required" yaml:"required"`
+}
+
+// loadCRDSchema reads path as either JSON or YAML and extracts its
+// top-level "required" list.
+func loadCRDSchema(path string) (*crdSchema, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CRD schema %s: %w", path, err)
+	}
+
+	var schema crdSchema
+	if jsonErr := json.Unmarshal(content, &schema); jsonErr != nil {
+		if yamlErr := goyaml.Unmarshal(content, &schema); yamlErr != nil {
+			return nil, fmt.Errorf("cannot parse CRD schema %s as JSON or YAML: %w", path, yamlErr)
+		}
+	}
+	return &schema, nil
+}
+
+// crdRule is the fallback Validator.Validate uses for any (apiVersion, kind)
+// kindDispatcher doesn't recognize, when Schema.CRDSchemaPath points at an
+// external OpenAPI/JSON-Schema document: it checks the document has every
+// field that schema's top-level "required" lists.
+type crdRule struct {
+	SchemaPath string
+}
+
+func (c crdRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	schema, err := loadCRDSchema(c.SchemaPath)
+	if err != nil {
+		return []*ValidationError{ctx.errorAt(node, "", err.Error())}
+	}
+
+	var errs []*ValidationError
+	for _, field := range schema.Required {
+		if lookupChild(node, field) == nil {
+			errs = append(errs, ctx.errorAt(node, field, fmt.Sprintf("%s is required", field)))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,78 @@
+package yamlvalidator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// replicasRule checks that spec.replicas is a non-negative integer.
+type replicasRule struct{}
+
+func (replicasRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "replicas must be int")}
+	}
+
+	replicas, err := strconv.Atoi(value)
+	if err != nil || replicas < 0 {
+		return []*ValidationError{ctx.errorAt(node, "", "replicas must be a non-negative int")}
+	}
+	return nil
+}
+
+// selectorMatchRule checks that a Deployment's spec.selector.matchLabels is
+// a subset of spec.template.metadata.labels, the way the Kubernetes API
+// server itself requires - a Deployment whose selector doesn't match its
+// own template can never adopt the Pods it creates. It's registered
+// alongside the RequiredFields mappingRule at the same "spec" Selector, so
+// both run against the same node.
+type selectorMatchRule struct{}
+
+func (selectorMatchRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	selectorNode := lookupPath(node, "selector", "matchLabels")
+	if selectorNode == nil {
+		return nil
+	}
+
+	selectorLabels := stringMap(selectorNode)
+	templateLabels := stringMap(lookupPath(node, "template", "metadata", "labels"))
+
+	for k, v := range selectorLabels {
+		if templateLabels[k] != v {
+			return []*ValidationError{ctx.errorAt(selectorNode, "", fmt.Sprintf("selector.matchLabels %s=%s not found in template labels", k, v))}
+		}
+	}
+	return nil
+}
+
+// registerDeploymentRules registers the apps/v1 Deployment rules: apiVersion
+// /kind/metadata like any other kind, spec.replicas, a selector/template
+// label match, and the Pod template's spec.containers - reusing the exact
+// container/probe/resource rules Pod validation registers, just mounted at
+// spec.template.spec.containers instead of spec.containers.
+func registerDeploymentRules(reg *RuleRegistry, schema Schema) {
+	const kind = "Deployment"
+
+	reg.Register(Selector{Kind: kind, Path: "apiVersion"}, apiVersionRule{Expected: "apps/v1"})
+	reg.Register(Selector{Kind: kind, Path: "kind"}, kindRule{Expected: kind})
+	reg.Register(Selector{Kind: kind, Path: "metadata"}, mappingRule{RequiredFields: []string{"name"}})
+	reg.Register(Selector{Kind: kind, Path: "metadata.name"}, nameRule{})
+	reg.Register(Selector{Kind: kind, Path: "metadata.labels"}, labelsRule{})
+
+	reg.Register(Selector{Kind: kind, Path: "spec"}, mappingRule{RequiredFields: []string{"replicas", "selector", "template"}})
+	reg.Register(Selector{Kind: kind, Path: "spec"}, selectorMatchRule{})
+	reg.Register(Selector{Kind: kind, Path: "spec.replicas"}, replicasRule{})
+
+	reg.Register(Selector{Kind: kind, Path: "spec.selector"}, mappingRule{RequiredFields: []string{"matchLabels"}})
+	reg.Register(Selector{Kind: kind, Path: "spec.selector.matchLabels"}, labelsRule{})
+
+	reg.Register(Selector{Kind: kind, Path: "spec.template"}, mappingRule{RequiredFields: []string{"metadata", "spec"}})
+	reg.Register(Selector{Kind: kind, Path: "spec.template.metadata"}, mappingRule{RequiredFields: []string{"labels"}})
+	reg.Register(Selector{Kind: kind, Path: "spec.template.metadata.labels"}, labelsRule{})
+	reg.Register(Selector{Kind: kind, Path: "spec.template.spec"}, mappingRule{RequiredFields: []string{"containers"}})
+
+	registerContainerRules(reg, kind, "spec.template.spec.containers", schema)
+}
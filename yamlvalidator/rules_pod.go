@@ -0,0 +1,365 @@
+package yamlvalidator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// mappingRule walks a YAML mapping node, dispatching each key it finds to
+// whatever rules are registered for ctx's Kind at the child path, and then
+// reports any RequiredFields that were never visited. It replaces the
+// repetitive "loop over Content, switch on key, check required fields"
+// blocks that used to be duplicated across validatePod/validateMetadata/
+// validateSpec/validateContainer/etc.
+type mappingRule struct {
+	RequiredFields []string
+}
+
+func (m mappingRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	var errs []*ValidationError
+	visited := make(map[string]bool)
+
+	if mapNode, ok := node.(ast.MapNode); ok {
+		it := mapNode.MapRange()
+		for it.Next() {
+			key, ok := scalarString(it.Key())
+			if !ok {
+				continue
+			}
+			visited[key] = true
+
+			childCtx := ctx.child(key)
+			for _, rule := range ctx.Registry.RulesFor(Selector{Kind: ctx.Kind, Path: childCtx.Path}) {
+				errs = append(errs, rule.Check(childCtx, it.Value())...)
+			}
+		}
+	}
+
+	for _, field := range m.RequiredFields {
+		if !visited[field] {
+			errs = append(errs, ctx.errorAt(node, field, fmt.Sprintf("%s is required", field)))
+		}
+	}
+
+	return errs
+}
+
+// sequenceRule checks that a node is a YAML sequence and re-dispatches each
+// item through item at the same path (items don't get an index segment, so
+// "spec.containers" rules apply to every container alike).
+type sequenceRule struct {
+	TypeName string
+	Item     Rule
+}
+
+func (s sequenceRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	seq, ok := node.(*ast.SequenceNode)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("%s must be an array", s.TypeName))}
+	}
+
+	var errs []*ValidationError
+	for _, itemNode := range seq.Values {
+		errs = append(errs, s.Item.Check(ctx, itemNode)...)
+	}
+	return errs
+}
+
+// apiVersionRule checks a document's apiVersion against the schema's
+// expected value.
+type apiVersionRule struct {
+	Expected string
+}
+
+func (a apiVersionRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok || value != a.Expected {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("apiVersion has unsupported value '%s'", value))}
+	}
+	return nil
+}
+
+// kindRule checks a document's kind against the schema's expected value.
+type kindRule struct {
+	Expected string
+}
+
+func (k kindRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok || value != k.Expected {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("kind has unsupported value '%s'", value))}
+	}
+	return nil
+}
+
+// nameRule checks that a node is a non-empty string, optionally matching it
+// against Pattern (used for container names but not for metadata.name).
+type nameRule struct {
+	CheckPattern bool
+	Pattern      *regexp.Regexp
+}
+
+func (n nameRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "name must be string")}
+	}
+
+	if value == "" {
+		return []*ValidationError{ctx.errorAt(node, "", "name is required")}
+	}
+
+	if n.CheckPattern && !n.Pattern.MatchString(value) {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("name has invalid format '%s'", value))}
+	}
+
+	return nil
+}
+
+type labelsRule struct{}
+
+func (labelsRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	mapNode, ok := node.(ast.MapNode)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "labels must be a mapping")}
+	}
+
+	var errs []*ValidationError
+	it := mapNode.MapRange()
+	for it.Next() {
+		if _, ok := scalarString(it.Value()); !ok {
+			errs = append(errs, ctx.errorAt(it.Value(), "", "label value must be string"))
+		}
+	}
+	return errs
+}
+
+// osRule checks a node against the schema's list of supported OS names.
+type osRule struct {
+	Supported []string
+}
+
+func (o osRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "os must be string")}
+	}
+
+	if !ContainsString(value, o.Supported) {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("os has unsupported value '%s'", value))}
+	}
+	return nil
+}
+
+// imageRule checks an image reference against the schema's Pattern.
+type imageRule struct {
+	Pattern *regexp.Regexp
+}
+
+func (img imageRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "image must be string")}
+	}
+
+	if !img.Pattern.MatchString(value) {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("image has invalid format '%s'", value))}
+	}
+	return nil
+}
+
+// portNumberRule validates an integer port field against the schema's
+// [Min, Max] range; FieldName customizes the message so the same rule
+// serves containerPort and the probe's httpGet port.
+type portNumberRule struct {
+	FieldName string
+	Min, Max  int
+}
+
+func (p portNumberRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("%s must be int", p.FieldName))}
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("%s must be int", p.FieldName))}
+	}
+
+	if port < p.Min || port > p.Max {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("%s value out of range", p.FieldName))}
+	}
+	return nil
+}
+
+// protocolRule checks a node against the schema's list of supported
+// protocols.
+type protocolRule struct {
+	Supported []string
+}
+
+func (p protocolRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "protocol must be string")}
+	}
+
+	if !ContainsString(value, p.Supported) {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("protocol has unsupported value '%s'", value))}
+	}
+	return nil
+}
+
+// httpPathRule checks a probe's httpGet.path against the schema's Pattern.
+type httpPathRule struct {
+	Pattern *regexp.Regexp
+}
+
+func (h httpPathRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "path must be string")}
+	}
+
+	if !h.Pattern.MatchString(value) {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("path has invalid format '%s'", value))}
+	}
+	return nil
+}
+
+type cpuRule struct{}
+
+func (cpuRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "cpu must be int")}
+	}
+
+	cpu, err := strconv.Atoi(value)
+	if err != nil || cpu < 1 {
+		return []*ValidationError{ctx.errorAt(node, "", "cpu value out of range")}
+	}
+	return nil
+}
+
+// memoryRule checks a memory quantity against the schema's Pattern, whose
+// first capture group must be the numeric amount.
+type memoryRule struct {
+	Pattern *regexp.Regexp
+}
+
+func (m memoryRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok {
+		return []*ValidationError{ctx.errorAt(node, "", "memory must be string")}
+	}
+
+	matches := m.Pattern.FindStringSubmatch(value)
+	if matches == nil {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("memory has invalid format '%s'", value))}
+	}
+
+	amount, err := strconv.Atoi(matches[1])
+	if err != nil || amount < 1 {
+		return []*ValidationError{ctx.errorAt(node, "", "memory value out of range")}
+	}
+	return nil
+}
+
+// resourcesRule dispatches each resource list (requests/limits) to the cpu
+// and memory rules registered under Path+".cpu"/".memory".
+type resourcesRule struct{}
+
+func (resourcesRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	mapNode, ok := node.(ast.MapNode)
+	if !ok {
+		return nil
+	}
+
+	var errs []*ValidationError
+	it := mapNode.MapRange()
+	for it.Next() {
+		errs = append(errs, mappingRule{}.Check(ctx, it.Value())...)
+	}
+	return errs
+}
+
+// podSelector scopes a Path under kind.
+func podSelector(kind, path string) Selector {
+	return Selector{Kind: kind, Path: path}
+}
+
+// registerContainerRules registers the name/image/resources/ports/probes
+// rules shared by any PodSpec-shaped "containers" field, at whatever prefix
+// the caller's document nests it under - "spec.containers" for a bare Pod,
+// "spec.template.spec.containers" for a Deployment's PodTemplateSpec - so
+// Deployment validation can reuse the exact rules Pod validation already
+// registers instead of duplicating them.
+func registerContainerRules(reg *RuleRegistry, kind, prefix string, schema Schema) {
+	containerRule := mappingRule{RequiredFields: []string{"name", "image", "resources"}}
+	reg.Register(Selector{Kind: kind, Path: prefix}, sequenceRule{TypeName: "containers", Item: containerRule})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".name"}, nameRule{CheckPattern: true, Pattern: schema.NameRegex})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".image"}, imageRule{Pattern: schema.ImageRegex})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".resources"}, resourcesRule{})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".resources.cpu"}, cpuRule{})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".resources.memory"}, memoryRule{Pattern: schema.MemoryRegex})
+
+	portRule := mappingRule{RequiredFields: []string{"containerPort"}}
+	reg.Register(Selector{Kind: kind, Path: prefix + ".ports"}, sequenceRule{TypeName: "ports", Item: portRule})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".ports.containerPort"}, portNumberRule{
+		FieldName: "containerPort", Min: schema.PortNumberMin, Max: schema.PortNumberMax,
+	})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".ports.protocol"}, protocolRule{Supported: schema.SupportedProtocols})
+
+	probeRule := mappingRule{RequiredFields: []string{"httpGet"}}
+	reg.Register(Selector{Kind: kind, Path: prefix + ".readinessProbe"}, probeRule)
+	reg.Register(Selector{Kind: kind, Path: prefix + ".livenessProbe"}, probeRule)
+
+	httpGetRule := mappingRule{RequiredFields: []string{"path", "port"}}
+	reg.Register(Selector{Kind: kind, Path: prefix + ".readinessProbe.httpGet"}, httpGetRule)
+	reg.Register(Selector{Kind: kind, Path: prefix + ".livenessProbe.httpGet"}, httpGetRule)
+	reg.Register(Selector{Kind: kind, Path: prefix + ".readinessProbe.httpGet.path"}, httpPathRule{Pattern: schema.PathRegex})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".livenessProbe.httpGet.path"}, httpPathRule{Pattern: schema.PathRegex})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".readinessProbe.httpGet.port"}, portNumberRule{
+		FieldName: "port", Min: schema.PortNumberMin, Max: schema.PortNumberMax,
+	})
+	reg.Register(Selector{Kind: kind, Path: prefix + ".livenessProbe.httpGet.port"}, portNumberRule{
+		FieldName: "port", Min: schema.PortNumberMin, Max: schema.PortNumberMax,
+	})
+}
+
+// registerPodRules registers the Pod rules backing schema: apiVersion,
+// kind, metadata (name, labels) and spec (os, containers with their
+// name/image/ports/probes/resources), all parameterized by schema's
+// patterns, ranges and allowed values.
+func registerPodRules(reg *RuleRegistry, schema Schema) {
+	kind := schema.Kind
+
+	reg.Register(podSelector(kind, "apiVersion"), apiVersionRule{Expected: schema.APIVersion})
+	reg.Register(podSelector(kind, "kind"), kindRule{Expected: schema.Kind})
+	reg.Register(podSelector(kind, "metadata"), mappingRule{RequiredFields: []string{"name"}})
+	reg.Register(podSelector(kind, "metadata.name"), nameRule{})
+	reg.Register(podSelector(kind, "metadata.labels"), labelsRule{})
+	reg.Register(podSelector(kind, "spec"), mappingRule{RequiredFields: []string{"containers"}})
+	reg.Register(podSelector(kind, "spec.os"), osRule{Supported: schema.SupportedOSNames})
+
+	registerContainerRules(reg, kind, "spec.containers", schema)
+}
+
+// DefaultRegistryFor builds the RuleRegistry backing schema: the Pod rules
+// schema configures, plus the built-in Deployment/Service/ConfigMap rules
+// kindDispatcher can route a document to by its declared apiVersion/kind.
+// Callers can register further rules - for other kinds, or additional
+// policies - on top of it.
+func DefaultRegistryFor(schema Schema) *RuleRegistry {
+	reg := NewRuleRegistry()
+	registerPodRules(reg, schema)
+	registerDeploymentRules(reg, schema)
+	registerServiceRules(reg, schema)
+	registerConfigMapRules(reg)
+	return reg
+}
@@ -0,0 +1,49 @@
+package yamlvalidator
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// supportedServiceTypes lists the v1 Service spec.type values this package
+// recognizes.
+var supportedServiceTypes = []string{"ClusterIP", "NodePort", "LoadBalancer", "ExternalName"}
+
+// serviceTypeRule checks spec.type against supportedServiceTypes.
+type serviceTypeRule struct{}
+
+func (serviceTypeRule) Check(ctx *ValidationContext, node ast.Node) []*ValidationError {
+	value, ok := scalarString(node)
+	if !ok || !ContainsString(value, supportedServiceTypes) {
+		return []*ValidationError{ctx.errorAt(node, "", fmt.Sprintf("type has unsupported value '%s'", value))}
+	}
+	return nil
+}
+
+// registerServiceRules registers the v1 Service rules: apiVersion/kind/
+// metadata like any other kind, spec.type, spec.selector labels, and
+// spec.ports (port, targetPort, protocol).
+func registerServiceRules(reg *RuleRegistry, schema Schema) {
+	const kind = "Service"
+
+	reg.Register(Selector{Kind: kind, Path: "apiVersion"}, apiVersionRule{Expected: "v1"})
+	reg.Register(Selector{Kind: kind, Path: "kind"}, kindRule{Expected: kind})
+	reg.Register(Selector{Kind: kind, Path: "metadata"}, mappingRule{RequiredFields: []string{"name"}})
+	reg.Register(Selector{Kind: kind, Path: "metadata.name"}, nameRule{})
+	reg.Register(Selector{Kind: kind, Path: "metadata.labels"}, labelsRule{})
+
+	reg.Register(Selector{Kind: kind, Path: "spec"}, mappingRule{RequiredFields: []string{"ports", "selector"}})
+	reg.Register(Selector{Kind: kind, Path: "spec.type"}, serviceTypeRule{})
+	reg.Register(Selector{Kind: kind, Path: "spec.selector"}, labelsRule{})
+
+	portRule := mappingRule{RequiredFields: []string{"port"}}
+	reg.Register(Selector{Kind: kind, Path: "spec.ports"}, sequenceRule{TypeName: "ports", Item: portRule})
+	reg.Register(Selector{Kind: kind, Path: "spec.ports.port"}, portNumberRule{
+		FieldName: "port", Min: schema.PortNumberMin, Max: schema.PortNumberMax,
+	})
+	reg.Register(Selector{Kind: kind, Path: "spec.ports.targetPort"}, portNumberRule{
+		FieldName: "targetPort", Min: schema.PortNumberMin, Max: schema.PortNumberMax,
+	})
+	reg.Register(Selector{Kind: kind, Path: "spec.ports.protocol"}, protocolRule{Supported: schema.SupportedProtocols})
+}
@@ -0,0 +1,37 @@
+package yamlvalidator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// scalarString extracts the canonical string form of a scalar node's value,
+// the way rules need it for pattern matching and equality checks. It mirrors
+// what gopkg.in/yaml.v3's node.Value used to give rules directly, since
+// ast.ScalarNode.GetValue returns a Go-typed value (string/uint64/int64/
+// float64/bool/nil) rather than the source text.
+func scalarString(node ast.Node) (string, bool) {
+	scalar, ok := node.(ast.ScalarNode)
+	if !ok {
+		return "", false
+	}
+
+	switch v := scalar.GetValue().(type) {
+	case string:
+		return v, true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case nil:
+		return "", true
+	default:
+		return fmt.Sprintf("%v", v), true
+	}
+}
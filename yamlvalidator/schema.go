@@ -0,0 +1,50 @@
+package yamlvalidator
+
+import "regexp"
+
+// Schema describes the set of rules Validator enforces: the apiVersion and
+// kind a document must declare, which OS names and protocols are allowed,
+// the patterns scalar fields must match, and the numeric ranges ports must
+// fall within. DefaultRegistryFor turns a Schema into the Rule registrations
+// that used to be hard-coded against the constants in constants.go, so
+// teams can run the same binary against a different registry, enforce
+// stricter naming, or allow new OS values purely through configuration.
+type Schema struct {
+	APIVersion string
+	Kind       string
+
+	SupportedOSNames   []string
+	SupportedProtocols []string
+
+	NameRegex   *regexp.Regexp
+	ImageRegex  *regexp.Regexp
+	MemoryRegex *regexp.Regexp
+	PathRegex   *regexp.Regexp
+
+	PortNumberMin int
+	PortNumberMax int
+
+	// CRDSchemaPath, if non-empty, points at an external OpenAPI/JSON-Schema
+	// document used to validate documents whose (apiVersion, kind) isn't one
+	// of the built-in kinds in kindDispatcher.
+	CRDSchemaPath string
+}
+
+// DefaultSchema returns the built-in Pod schema: apiVersion "v1", kind
+// "Pod", registry.bigbrother.io images, linux/windows containers and
+// TCP/UDP ports. It is the schema NewValidator falls back to when no
+// Schema override is given.
+func DefaultSchema() Schema {
+	return Schema{
+		APIVersion:         APIVersionExpected,
+		Kind:               KindExpected,
+		SupportedOSNames:   SupportedOSNames,
+		SupportedProtocols: SupportedProtocols,
+		NameRegex:          RegexSnakeCase,
+		ImageRegex:         RegexImage,
+		MemoryRegex:        RegexMemory,
+		PathRegex:          RegexAbsolutePath,
+		PortNumberMin:      PortNumberMin,
+		PortNumberMax:      PortNumberMax,
+	}
+}